@@ -31,13 +31,26 @@ type UnexpectedStateError struct {
 	LastError     error
 	State         string
 	ExpectedState []string
+
+	// ExpectedPredicate is true when StateChangeConf.TargetFunc was also
+	// used to decide whether the target was reached.
+	ExpectedPredicate bool
 }
 
 func (e *UnexpectedStateError) Error() string {
+	expectedState := fmt.Sprintf("target '%s'", strings.Join(e.ExpectedState, ", "))
+	if e.ExpectedPredicate {
+		if len(e.ExpectedState) > 0 {
+			expectedState += " or target predicate to be true"
+		} else {
+			expectedState = "target predicate to be true"
+		}
+	}
+
 	return fmt.Sprintf(
-		"unexpected state '%s', wanted target '%s'. last error: %s",
+		"unexpected state '%s', wanted %s. last error: %s",
 		e.State,
-		strings.Join(e.ExpectedState, ", "),
+		expectedState,
 		e.LastError,
 	)
 }
@@ -48,12 +61,21 @@ type TimeoutError struct {
 	LastState     string
 	Timeout       time.Duration
 	ExpectedState []string
+
+	// ExpectedPredicate is true when StateChangeConf.TargetFunc was also
+	// used to decide whether the target was reached.
+	ExpectedPredicate bool
 }
 
 func (e *TimeoutError) Error() string {
 	expectedState := "resource to be gone"
 	if len(e.ExpectedState) > 0 {
 		expectedState = fmt.Sprintf("state to become '%s'", strings.Join(e.ExpectedState, ", "))
+		if e.ExpectedPredicate {
+			expectedState += " or target predicate to be true"
+		}
+	} else if e.ExpectedPredicate {
+		expectedState = "target predicate to be true"
 	}
 
 	extraInfo := make([]string, 0)
@@ -19,12 +19,23 @@ var refreshGracePeriod = 30 * time.Second
 // may have happened while refreshing the state.
 type StateRefreshFunc func() (result interface{}, state string, err error)
 
+// StateTargetFunc is a function type used for StateChangeConf that decides
+// whether the object returned by the most recent refresh has reached the
+// target. It allows a target to be expressed as a predicate over the whole
+// object, such as a combination of several status fields, rather than as a
+// single state string.
+//
+// It is called with the `result` and `state` returned by the
+// StateRefreshFunc, and only when `result` is not nil.
+type StateTargetFunc func(result interface{}, state string) bool
+
 // StateChangeConf is the configuration struct used for `WaitForState`.
 type StateChangeConf struct {
 	Delay          time.Duration    // Wait this time before starting checks
 	Pending        []string         // States that are "allowed" and will continue trying
 	Refresh        StateRefreshFunc // Refreshes the current state
 	Target         []string         // Target state
+	TargetFunc     StateTargetFunc  // Target predicate, checked after Target
 	Timeout        time.Duration    // The amount of time to wait before timeout
 	MinTimeout     time.Duration    // Smallest time to wait before refreshes
 	PollInterval   time.Duration    // Override MinTimeout/backoff and only poll this often
@@ -41,7 +52,14 @@ type StateChangeConf struct {
 // If the Refresh function returns a error, exit immediately with that error.
 //
 // If the Refresh function returns a state other than the Target state or one
-// listed in Pending, return immediately with an error.
+// listed in Pending, return immediately with an error. When TargetFunc is set,
+// an object for which it returns true is also considered to have reached the
+// target, even if its state is listed in Pending. Any state in which
+// TargetFunc can still return false, such as "active" while a separate health
+// field is still "initializing", must therefore be listed in Pending, or the
+// wait fails as soon as it is seen instead of polling again. With no Pending
+// states at all, every state is allowed and polling continues until the
+// target is reached or the Timeout is exceeded.
 //
 // If the Timeout is exceeded before reaching the Target state, return an
 // error.
@@ -49,7 +67,14 @@ type StateChangeConf struct {
 // Otherwise, result the result of the first call to the Refresh function to
 // reach the target state.
 func (conf *StateChangeConf) WaitForState() (interface{}, error) {
-	log.Printf("[DEBUG] Waiting for state to become: %s", conf.Target)
+	switch {
+	case conf.TargetFunc != nil && len(conf.Target) == 0:
+		log.Printf("[DEBUG] Waiting for target predicate to be true")
+	case conf.TargetFunc != nil:
+		log.Printf("[DEBUG] Waiting for state to become: %s, or target predicate to be true", conf.Target)
+	default:
+		log.Printf("[DEBUG] Waiting for state to become: %s", conf.Target)
+	}
 
 	notfoundTick := 0
 	targetOccurence := 0
@@ -113,7 +138,7 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 			}
 
 			// If we're waiting for the absence of a thing, then return
-			if res == nil && len(conf.Target) == 0 {
+			if res == nil && len(conf.Target) == 0 && conf.TargetFunc == nil {
 				targetOccurence++
 				if conf.ContinuousTargetOccurence == targetOccurence {
 					result.Done = true
@@ -153,8 +178,26 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 					}
 				}
 
+				matched := false
+				if !found && conf.TargetFunc != nil {
+					if conf.TargetFunc(res, currentState) {
+						found = true
+						matched = true
+						targetOccurence++
+						if conf.ContinuousTargetOccurence == targetOccurence {
+							result.Done = true
+							resCh <- result
+							return
+						}
+					} else {
+						// The target has to be reached continuously, so
+						// a miss starts the count over.
+						targetOccurence = 0
+					}
+				}
+
 				for _, allowed := range conf.Pending {
-					if currentState == allowed {
+					if !matched && currentState == allowed {
 						found = true
 						targetOccurence = 0
 						break
@@ -163,9 +206,10 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 
 				if !found && len(conf.Pending) > 0 {
 					result.Error = &UnexpectedStateError{
-						LastError:     err,
-						State:         result.State,
-						ExpectedState: conf.Target,
+						LastError:         err,
+						State:             result.State,
+						ExpectedState:     conf.Target,
+						ExpectedPredicate: conf.TargetFunc != nil,
 					}
 					resCh <- result
 					return
@@ -249,10 +293,11 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 			}
 
 			return nil, &TimeoutError{
-				LastError:     lastResult.Error,
-				LastState:     lastResult.State,
-				Timeout:       conf.Timeout,
-				ExpectedState: conf.Target,
+				LastError:         lastResult.Error,
+				LastState:         lastResult.State,
+				Timeout:           conf.Timeout,
+				ExpectedState:     conf.Target,
+				ExpectedPredicate: conf.TargetFunc != nil,
 			}
 		}
 	}
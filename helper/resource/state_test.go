@@ -266,6 +266,162 @@ func TestWaitForState_successUnknownPending(t *testing.T) {
 	}
 }
 
+func TestWaitForState_successTargetFunc(t *testing.T) {
+	r := NewStateGenerator([]string{"activating", "active", "active"})
+	healthy := []bool{false, false, true}
+
+	conf := &StateChangeConf{
+		Pending: []string{"activating", "active"},
+		Refresh: func() (interface{}, string, error) {
+			idx, s, err := r.NextState()
+			if err != nil {
+				return nil, "", err
+			}
+			return idx, s, nil
+		},
+		TargetFunc: func(res interface{}, state string) bool {
+			return state == "active" && healthy[res.(int)]
+		},
+		Timeout:      1 * time.Second,
+		PollInterval: 10 * time.Millisecond,
+	}
+
+	idx, err := conf.WaitForState()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if idx != 2 {
+		t.Fatalf("Expected index 2, given %v", idx)
+	}
+}
+
+func TestWaitForState_successTargetFuncNoPending(t *testing.T) {
+	r := NewStateGenerator([]string{"activating", "active", "active"})
+	healthy := []bool{false, false, true}
+
+	conf := &StateChangeConf{
+		Refresh: func() (interface{}, string, error) {
+			idx, s, err := r.NextState()
+			if err != nil {
+				return nil, "", err
+			}
+			return idx, s, nil
+		},
+		TargetFunc: func(res interface{}, state string) bool {
+			return state == "active" && healthy[res.(int)]
+		},
+		Timeout:      1 * time.Second,
+		PollInterval: 10 * time.Millisecond,
+	}
+
+	idx, err := conf.WaitForState()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if idx != 2 {
+		t.Fatalf("Expected index 2, given %v", idx)
+	}
+}
+
+func TestWaitForState_inconsistentTargetFunc(t *testing.T) {
+	r := NewStateGenerator([]string{"active", "active", "active", "active", "active", "active"})
+	healthy := []bool{false, true, false, true, true, false}
+
+	conf := &StateChangeConf{
+		Refresh: func() (interface{}, string, error) {
+			idx, s, err := r.NextState()
+			if err != nil {
+				return nil, "", err
+			}
+			return idx, s, nil
+		},
+		TargetFunc: func(res interface{}, state string) bool {
+			return healthy[res.(int)]
+		},
+		Timeout:                   1 * time.Second,
+		PollInterval:              10 * time.Millisecond,
+		ContinuousTargetOccurence: 2,
+	}
+
+	idx, err := conf.WaitForState()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if idx != 4 {
+		t.Fatalf("Expected index 4, given %v", idx)
+	}
+}
+
+func TestWaitForState_failureTargetAndTargetFunc(t *testing.T) {
+	conf := &StateChangeConf{
+		Pending: []string{"activating"},
+		Target:  []string{"healthy"},
+		Refresh: func() (interface{}, string, error) {
+			return 42, "stopped", nil
+		},
+		TargetFunc: func(res interface{}, state string) bool {
+			return false
+		},
+		Timeout: 200 * time.Second,
+	}
+
+	_, err := conf.WaitForState()
+	if err == nil {
+		t.Fatal("Expected error. No error returned.")
+	}
+	expectedErr := "unexpected state 'stopped', wanted target 'healthy' or target predicate to be true. last error: %!s(<nil>)"
+	if err.Error() != expectedErr {
+		t.Fatalf("Errors don't match.\nExpected: %q\nGiven: %q\n", expectedErr, err.Error())
+	}
+}
+
+func TestWaitForState_failureTargetFunc(t *testing.T) {
+	// "active" is not listed in Pending, so the unhealthy "active" object
+	// fails the wait instead of being polled again.
+	conf := &StateChangeConf{
+		Pending: []string{"activating"},
+		Refresh: func() (interface{}, string, error) {
+			return 42, "active", nil
+		},
+		TargetFunc: func(res interface{}, state string) bool {
+			return false
+		},
+		Timeout: 200 * time.Second,
+	}
+
+	_, err := conf.WaitForState()
+	if err == nil {
+		t.Fatal("Expected error. No error returned.")
+	}
+	expectedErr := "unexpected state 'active', wanted target predicate to be true. last error: %!s(<nil>)"
+	if err.Error() != expectedErr {
+		t.Fatalf("Errors don't match.\nExpected: %q\nGiven: %q\n", expectedErr, err.Error())
+	}
+}
+
+func TestWaitForState_timeoutTargetFunc(t *testing.T) {
+	conf := &StateChangeConf{
+		Pending: []string{"active"},
+		Refresh: func() (interface{}, string, error) {
+			return 42, "active", nil
+		},
+		TargetFunc: func(res interface{}, state string) bool {
+			return false
+		},
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      100 * time.Millisecond,
+	}
+
+	_, err := conf.WaitForState()
+	if err == nil {
+		t.Fatal("Expected timeout error. Got none.")
+	}
+	expectedErr := "timeout while waiting for target predicate to be true (last state: 'active', timeout: 100ms)"
+	if err.Error() != expectedErr {
+		t.Fatalf("Errors don't match.\nExpected: %q\nGiven: %q\n", expectedErr, err.Error())
+	}
+}
+
 func TestWaitForState_successEmpty(t *testing.T) {
 	conf := &StateChangeConf{
 		Pending: []string{"pending", "incomplete"},
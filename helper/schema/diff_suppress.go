@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"strings"
+)
+
+// MapKeysDiffSuppressFunc returns a SchemaDiffSuppressFunc for TypeMap
+// fields that hides differences in keys managed outside of Terraform, such
+// as labels that a remote system adds to an object on its own. A key is
+// ignored when it is equal to one of keys or begins with one of prefixes,
+// and it is only hidden while it is absent from the config: a matching key
+// that is set in the config is diffed like any other.
+//
+// The element count of the map is only reported as changed when the number
+// of keys that are not hidden differs between the state and the config.
+func MapKeysDiffSuppressFunc(keys, prefixes []string) SchemaDiffSuppressFunc {
+	ignored := func(key string) bool {
+		for _, k := range keys {
+			if key == k {
+				return true
+			}
+		}
+		for _, p := range prefixes {
+			if strings.HasPrefix(key, p) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return func(k, old, new string, d *ResourceData) bool {
+		mapK, key, ok := splitMapKey(k, d)
+		if !ok {
+			return false
+		}
+
+		o, n, _, _ := d.diffChange(mapK)
+		stateMap, _ := o.(map[string]interface{})
+		configMap, _ := n.(map[string]interface{})
+
+		if key != "%" {
+			_, configured := configMap[key]
+			return !configured && ignored(key)
+		}
+
+		// A count without a new value means the map is computed, which
+		// we never want to hide.
+		if new == "" {
+			return false
+		}
+
+		count := 0
+		for key := range stateMap {
+			if _, configured := configMap[key]; configured || !ignored(key) {
+				count++
+			}
+		}
+
+		return count == len(configMap)
+	}
+}
+
+// splitMapKey splits the flatmapped key of a map element into the address
+// of the map field and the key within the map. Map keys may contain dots,
+// so the schema is used to find where the address of the field ends.
+func splitMapKey(k string, d *ResourceData) (string, string, bool) {
+	if d == nil {
+		return "", "", false
+	}
+
+	for i := 0; i < len(k); i++ {
+		if k[i] != '.' {
+			continue
+		}
+
+		schemaList := addrToSchema(strings.Split(k[:i], "."), d.schema)
+		if len(schemaList) > 0 && schemaList[len(schemaList)-1].Type == TypeMap {
+			return k[:i], k[i+1:], true
+		}
+	}
+
+	return "", "", false
+}
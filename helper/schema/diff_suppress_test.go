@@ -0,0 +1,267 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/hil/ast"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestMapKeysDiffSuppressFunc(t *testing.T) {
+	labels := &Schema{
+		Type:     TypeMap,
+		Optional: true,
+		DiffSuppressFunc: MapKeysDiffSuppressFunc(
+			[]string{"managed.key"}, []string{"io.rancher."}),
+	}
+
+	cases := map[string]struct {
+		Schema       map[string]*Schema
+		State        *terraform.InstanceState
+		Config       map[string]interface{}
+		ExpectedDiff *terraform.InstanceDiff
+	}{
+		"ignored keys only in state": {
+			Schema: map[string]*Schema{
+				"labels": labels,
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"labels.%":                  "3",
+					"labels.foo":                "bar",
+					"labels.managed.key":        "a",
+					"labels.io.rancher.host.os": "linux",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"foo": "bar",
+				},
+			},
+
+			ExpectedDiff: nil,
+		},
+
+		"changed key alongside ignored keys": {
+			Schema: map[string]*Schema{
+				"labels": labels,
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"labels.%":                  "2",
+					"labels.foo":                "bar",
+					"labels.io.rancher.host.os": "linux",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"foo": "baz",
+				},
+			},
+
+			ExpectedDiff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"labels.foo": &terraform.ResourceAttrDiff{
+						Old: "bar",
+						New: "baz",
+					},
+				},
+			},
+		},
+
+		"removed key alongside ignored keys": {
+			Schema: map[string]*Schema{
+				"labels": labels,
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"labels.%":                  "2",
+					"labels.foo":                "bar",
+					"labels.io.rancher.host.os": "linux",
+				},
+			},
+
+			Config: map[string]interface{}{},
+
+			ExpectedDiff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"labels.%": &terraform.ResourceAttrDiff{
+						Old: "2",
+						New: "0",
+					},
+					"labels.foo": &terraform.ResourceAttrDiff{
+						Old:        "bar",
+						NewRemoved: true,
+					},
+				},
+			},
+		},
+
+		"ignored key set in config on create": {
+			Schema: map[string]*Schema{
+				"labels": labels,
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"io.rancher.scheduler.affinity:host_label": "a=b",
+				},
+			},
+
+			ExpectedDiff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"labels.%": &terraform.ResourceAttrDiff{
+						Old: "0",
+						New: "1",
+					},
+					"labels.io.rancher.scheduler.affinity:host_label": &terraform.ResourceAttrDiff{
+						Old: "",
+						New: "a=b",
+					},
+				},
+			},
+		},
+
+		"ignored key set in config on update": {
+			Schema: map[string]*Schema{
+				"labels": labels,
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"labels.%":                           "2",
+					"labels.io.rancher.scheduler.global": "true",
+					"labels.io.rancher.host.os":          "linux",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"io.rancher.scheduler.global": "false",
+				},
+			},
+
+			ExpectedDiff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"labels.io.rancher.scheduler.global": &terraform.ResourceAttrDiff{
+						Old: "true",
+						New: "false",
+					},
+				},
+			},
+		},
+
+		"nested map": {
+			Schema: map[string]*Schema{
+				"driver": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"config": labels,
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"driver.#":                    "1",
+					"driver.0.config.%":           "2",
+					"driver.0.config.foo":         "bar",
+					"driver.0.config.managed.key": "a",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"driver": []map[string]interface{}{
+					map[string]interface{}{
+						"config": map[string]interface{}{
+							"foo": "bar",
+						},
+					},
+				},
+			},
+
+			ExpectedDiff: nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			c, err := config.NewRawConfig(tc.Config)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			d, err := schemaMap(tc.Schema).Diff(
+				tc.State, terraform.NewResourceConfig(c))
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(tc.ExpectedDiff, d) {
+				t.Fatalf("expected:\n%#v\n\ngot:\n%#v", tc.ExpectedDiff, d)
+			}
+		})
+	}
+}
+
+func TestMapKeysDiffSuppressFunc_computed(t *testing.T) {
+	s := map[string]*Schema{
+		"labels": &Schema{
+			Type:     TypeMap,
+			Optional: true,
+			DiffSuppressFunc: MapKeysDiffSuppressFunc(
+				nil, []string{"io.rancher."}),
+		},
+	}
+
+	state := &terraform.InstanceState{
+		Attributes: map[string]string{
+			"labels.%":                  "2",
+			"labels.foo":                "bar",
+			"labels.io.rancher.host.os": "linux",
+		},
+	}
+
+	c, err := config.NewRawConfig(map[string]interface{}{
+		"labels": map[string]interface{}{
+			"foo": "${var.foo}",
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	err = c.Interpolate(map[string]ast.Variable{
+		"var.foo": interfaceToVariableSwallowError(config.UnknownVariableValue),
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d, err := schemaMap(s).Diff(state, terraform.NewResourceConfig(c))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d == nil {
+		t.Fatal("expected a diff")
+	}
+
+	count, ok := d.Attributes["labels.%"]
+	if !ok || !count.NewComputed {
+		t.Fatalf("expected labels.%% to be computed, got: %#v", count)
+	}
+	if attr, ok := d.Attributes["labels.io.rancher.host.os"]; ok {
+		t.Fatalf("expected ignored key to be hidden, got: %#v", attr)
+	}
+}
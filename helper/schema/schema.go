@@ -162,6 +162,15 @@ type Schema struct {
 	// key.
 	ConflictsWith []string
 
+	// ExactlyOneOf is a set of schema keys that, together with this schema,
+	// must have exactly one of their values set in the _config_. It is
+	// useful for mutually exclusive blocks where one of them is required,
+	// such as per-driver configuration. Keys of nested attributes use the
+	// same form as ConflictsWith, e.g. "driver.0.config". Only when none of
+	// them is set in the config does a value from Default or DefaultFunc
+	// satisfy the constraint.
+	ExactlyOneOf []string
+
 	// When Deprecated is set, this attribute is deprecated.
 	//
 	// A deprecated field still works, but will probably stop working in near
@@ -583,35 +592,20 @@ func (m schemaMap) InternalValidate(topSchemaMap schemaMap) error {
 		}
 
 		if len(v.ConflictsWith) > 0 {
-			for _, key := range v.ConflictsWith {
-				parts := strings.Split(key, ".")
-				sm := topSchemaMap
-				var target *Schema
-				for _, part := range parts {
-					// Skip index fields
-					if _, err := strconv.Atoi(part); err == nil {
-						continue
-					}
-
-					var ok bool
-					if target, ok = sm[part]; !ok {
-						return fmt.Errorf("%s: ConflictsWith references unknown attribute (%s)", k, key)
-					}
+			err := checkKeysAgainstSchemaFlags(k, "ConflictsWith", v.ConflictsWith, topSchemaMap)
+			if err != nil {
+				return err
+			}
+		}
 
-					if subResource, ok := target.Elem.(*Resource); ok {
-						sm = schemaMap(subResource.Schema)
-					}
-				}
-				if target == nil {
-					return fmt.Errorf("%s: ConflictsWith cannot find target attribute (%s), sm: %#v", k, key, sm)
-				}
-				if target.Required {
-					return fmt.Errorf("%s: ConflictsWith cannot contain Required attribute (%s)", k, key)
-				}
+		if len(v.ExactlyOneOf) > 0 && v.Required {
+			return fmt.Errorf("%s: ExactlyOneOf cannot be set with Required", k)
+		}
 
-				if len(target.ComputedWhen) > 0 {
-					return fmt.Errorf("%s: ConflictsWith cannot contain Computed(When) attribute (%s)", k, key)
-				}
+		if len(v.ExactlyOneOf) > 0 {
+			err := checkKeysAgainstSchemaFlags(k, "ExactlyOneOf", v.ExactlyOneOf, topSchemaMap)
+			if err != nil {
+				return err
 			}
 		}
 
@@ -676,6 +670,44 @@ func (m schemaMap) InternalValidate(topSchemaMap schemaMap) error {
 	return nil
 }
 
+// checkKeysAgainstSchemaFlags verifies that the keys referenced by the
+// attribute k through the named field exist in the schema and can be
+// left unset in the configuration.
+func checkKeysAgainstSchemaFlags(k, field string, keys []string, topSchemaMap schemaMap) error {
+	for _, key := range keys {
+		parts := strings.Split(key, ".")
+		sm := topSchemaMap
+		var target *Schema
+		for _, part := range parts {
+			// Skip index fields
+			if _, err := strconv.Atoi(part); err == nil {
+				continue
+			}
+
+			var ok bool
+			if target, ok = sm[part]; !ok {
+				return fmt.Errorf("%s: %s references unknown attribute (%s)", k, field, key)
+			}
+
+			if subResource, ok := target.Elem.(*Resource); ok {
+				sm = schemaMap(subResource.Schema)
+			}
+		}
+		if target == nil {
+			return fmt.Errorf("%s: %s cannot find target attribute (%s), sm: %#v", k, field, key, sm)
+		}
+		if target.Required {
+			return fmt.Errorf("%s: %s cannot contain Required attribute (%s)", k, field, key)
+		}
+
+		if len(target.ComputedWhen) > 0 {
+			return fmt.Errorf("%s: %s cannot contain Computed(When) attribute (%s)", k, field, key)
+		}
+	}
+
+	return nil
+}
+
 func isValidFieldName(name string) bool {
 	re := regexp.MustCompile("^[a-z0-9_]+$")
 	return re.MatchString(name)
@@ -1181,6 +1213,76 @@ func (m schemaMap) validateConflictingAttributes(
 	return nil
 }
 
+// exactlyOneOfKeys returns the sorted set of keys in the ExactlyOneOf
+// group of the attribute k, including k itself.
+func exactlyOneOfKeys(k string, schema *Schema) []string {
+	keys := append([]string{}, schema.ExactlyOneOf...)
+	found := false
+	for _, key := range keys {
+		if key == k {
+			found = true
+			break
+		}
+	}
+	if !found {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (m schemaMap) validateExactlyOneAttribute(
+	k string,
+	keys []string,
+	c *terraform.ResourceConfig) error {
+
+	specified := make([]string, 0, 1)
+	computed := 0
+	for _, key := range keys {
+		// A computed value may or may not end up set, so it counts for
+		// neither side until it is known.
+		if c.IsComputed(key) {
+			computed++
+			continue
+		}
+
+		if _, ok := c.Get(key); ok {
+			specified = append(specified, key)
+		}
+	}
+
+	if len(specified) > 1 {
+		return fmt.Errorf(
+			"%q: only one of %s can be set, got %s",
+			k, strings.Join(keys, ", "), strings.Join(specified, ", "))
+	}
+
+	if len(specified) == 1 || computed > 0 {
+		return nil
+	}
+
+	// Nothing is set in the config, so a value supplied by Default or
+	// DefaultFunc, such as credentials read from the environment, will do.
+	for _, key := range keys {
+		schemaList := addrToSchema(strings.Split(key, "."), m)
+		if len(schemaList) == 0 {
+			continue
+		}
+
+		v, err := schemaList[len(schemaList)-1].DefaultValue()
+		if err != nil {
+			return fmt.Errorf("%q, %s", key, err)
+		}
+		if v != nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"%q: one of %s must be set", k, strings.Join(keys, ", "))
+}
+
 func (m schemaMap) validateList(
 	k string,
 	raw interface{},
@@ -1409,6 +1511,38 @@ func (m schemaMap) validateObject(
 		}
 	}
 
+	// Every member of an ExactlyOneOf group carries the same constraint, so
+	// check each group once, reporting it against its first member here.
+	subKeys := make([]string, 0, len(schema))
+	for subK := range schema {
+		subKeys = append(subKeys, subK)
+	}
+	sort.Strings(subKeys)
+
+	groups := make(map[string]struct{})
+	for _, subK := range subKeys {
+		s := schema[subK]
+		if len(s.ExactlyOneOf) == 0 {
+			continue
+		}
+
+		key := subK
+		if k != "" {
+			key = fmt.Sprintf("%s.%s", k, subK)
+		}
+
+		keys := exactlyOneOfKeys(key, s)
+		group := strings.Join(keys, ",")
+		if _, ok := groups[group]; ok {
+			continue
+		}
+		groups[group] = struct{}{}
+
+		if err := m.validateExactlyOneAttribute(key, keys, c); err != nil {
+			es = append(es, err)
+		}
+	}
+
 	// Detect any extra/unknown keys and report those as errors.
 	if m, ok := raw.(map[string]interface{}); ok {
 		for subk, _ := range m {
@@ -3280,6 +3280,55 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			true,
 		},
 
+		"ExactlyOneOf cannot be set with Required": {
+			map[string]*Schema{
+				"blacklist": &Schema{
+					Type:     TypeBool,
+					Optional: true,
+				},
+				"whitelist": &Schema{
+					Type:         TypeBool,
+					Required:     true,
+					ExactlyOneOf: []string{"blacklist"},
+				},
+			},
+			true,
+		},
+
+		"ExactlyOneOf references unknown attribute": {
+			map[string]*Schema{
+				"whitelist": &Schema{
+					Type:         TypeBool,
+					Optional:     true,
+					ExactlyOneOf: []string{"greylist"},
+				},
+			},
+			true,
+		},
+
+		"ExactlyOneOf referencing nested attribute": {
+			map[string]*Schema{
+				"driver": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"config": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"driver_config": &Schema{
+					Type:         TypeString,
+					Optional:     true,
+					ExactlyOneOf: []string{"driver.0.config"},
+				},
+			},
+			false,
+		},
+
 		"Sub-resource invalid": {
 			map[string]*Schema{
 				"foo": &Schema{
@@ -3703,6 +3752,60 @@ func TestSchemaMap_DiffSuppress(t *testing.T) {
 }
 
 func TestSchemaMap_Validate(t *testing.T) {
+	validateLowercase := func(v interface{}, k string) (ws []string, es []error) {
+		if s := v.(string); s != strings.ToLower(s) {
+			es = append(es, fmt.Errorf("must be lowercase"))
+		}
+		return
+	}
+
+	exactlyOneOfSchema := map[string]*Schema{
+		"digitalocean_config": &Schema{
+			Type:         TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"amazonec2_config", "digitalocean_config"},
+			Elem: &Resource{
+				Schema: map[string]*Schema{
+					"size": &Schema{
+						Type:     TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"amazonec2_config": &Schema{
+			Type:         TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"amazonec2_config", "digitalocean_config"},
+			Elem: &Resource{
+				Schema: map[string]*Schema{
+					"region": &Schema{
+						Type:     TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	exactlyOneOfDefaultSchema := map[string]*Schema{
+		"token": &Schema{
+			Type:         TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"token", "token_file"},
+			DefaultFunc: func() (interface{}, error) {
+				return "env-token", nil
+			},
+		},
+		"token_file": &Schema{
+			Type:         TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"token", "token_file"},
+		},
+	}
+
 	cases := map[string]struct {
 		Schema   map[string]*Schema
 		Config   map[string]interface{}
@@ -4510,6 +4613,185 @@ func TestSchemaMap_Validate(t *testing.T) {
 			Err: false,
 		},
 
+		"ExactlyOneOf with one block set": {
+			Schema: exactlyOneOfSchema,
+
+			Config: map[string]interface{}{
+				"digitalocean_config": []interface{}{
+					map[string]interface{}{
+						"size": "1gb",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"ExactlyOneOf with no block set": {
+			Schema: exactlyOneOfSchema,
+
+			Config: map[string]interface{}{},
+
+			Err: true,
+			Errors: []error{
+				fmt.Errorf(`"amazonec2_config": one of amazonec2_config, digitalocean_config must be set`),
+			},
+		},
+
+		"ExactlyOneOf with both blocks set": {
+			Schema: exactlyOneOfSchema,
+
+			Config: map[string]interface{}{
+				"digitalocean_config": []interface{}{
+					map[string]interface{}{
+						"size": "1gb",
+					},
+				},
+				"amazonec2_config": []interface{}{
+					map[string]interface{}{
+						"region": "us-east-1",
+					},
+				},
+			},
+
+			Err: true,
+			Errors: []error{
+				fmt.Errorf(`"amazonec2_config": only one of amazonec2_config, digitalocean_config can be set, got amazonec2_config, digitalocean_config`),
+			},
+		},
+
+		"ExactlyOneOf with value from DefaultFunc": {
+			Schema: exactlyOneOfDefaultSchema,
+
+			Config: map[string]interface{}{},
+
+			Err: false,
+		},
+
+		"ExactlyOneOf with config value overriding DefaultFunc": {
+			Schema: exactlyOneOfDefaultSchema,
+
+			Config: map[string]interface{}{
+				"token_file": "/tmp/token",
+			},
+
+			Err: false,
+		},
+
+		"ExactlyOneOf with config value overriding Default": {
+			Schema: map[string]*Schema{
+				"a": &Schema{
+					Type:         TypeString,
+					Optional:     true,
+					Default:      "x",
+					ExactlyOneOf: []string{"a", "b"},
+				},
+				"b": &Schema{
+					Type:         TypeString,
+					Optional:     true,
+					ExactlyOneOf: []string{"a", "b"},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"b": "z",
+			},
+
+			Err: false,
+		},
+
+		"ExactlyOneOf reported along with nested errors": {
+			Schema: map[string]*Schema{
+				"digitalocean_config": &Schema{
+					Type:         TypeList,
+					Optional:     true,
+					ExactlyOneOf: []string{"amazonec2_config", "digitalocean_config"},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"size": &Schema{
+								Type:     TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+				"amazonec2_config": &Schema{
+					Type:         TypeList,
+					Optional:     true,
+					ExactlyOneOf: []string{"amazonec2_config", "digitalocean_config"},
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"region": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"digitalocean_config": []interface{}{
+					map[string]interface{}{},
+				},
+				"amazonec2_config": []interface{}{
+					map[string]interface{}{
+						"region": "us-east-1",
+					},
+				},
+			},
+
+			Err: true,
+			Errors: []error{
+				fmt.Errorf(`"amazonec2_config": only one of amazonec2_config, digitalocean_config can be set, got amazonec2_config, digitalocean_config`),
+				fmt.Errorf(`"digitalocean_config.0.size": required field is not set`),
+			},
+		},
+
+		"Equal errors from different attributes are all reported": {
+			Schema: map[string]*Schema{
+				"a": &Schema{
+					Type:         TypeString,
+					Optional:     true,
+					ValidateFunc: validateLowercase,
+				},
+				"b": &Schema{
+					Type:         TypeString,
+					Optional:     true,
+					ValidateFunc: validateLowercase,
+				},
+			},
+
+			Config: map[string]interface{}{
+				"a": "A",
+				"b": "B",
+			},
+
+			Err: true,
+			Errors: []error{
+				fmt.Errorf("must be lowercase"),
+				fmt.Errorf("must be lowercase"),
+			},
+		},
+
+		"ExactlyOneOf with computed block": {
+			Schema: exactlyOneOfSchema,
+
+			Config: map[string]interface{}{
+				"amazonec2_config": []interface{}{
+					map[string]interface{}{
+						"region": "${var.foo}",
+					},
+				},
+			},
+
+			Vars: map[string]string{
+				"var.foo": config.UnknownVariableValue,
+			},
+
+			Err: false,
+		},
+
 		"Good with ValidateFunc": {
 			Schema: map[string]*Schema{
 				"validate_me": &Schema{